@@ -0,0 +1,267 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// overflowKind identifies the behavior an OverflowPolicy selects.
+type overflowKind int
+
+const (
+	overflowDropOldest overflowKind = iota
+	overflowDropNewest
+	overflowBlock
+	overflowBlockWithTimeout
+)
+
+// OverflowPolicy controls what an async logger does when its queue is
+// full.
+type OverflowPolicy struct {
+	kind    overflowKind
+	timeout time.Duration
+}
+
+var (
+	// DropOldest discards the oldest queued entry to make room for the
+	// new one.
+	DropOldest = OverflowPolicy{kind: overflowDropOldest}
+	// DropNewest discards the incoming entry, keeping the queue as is.
+	DropNewest = OverflowPolicy{kind: overflowDropNewest}
+	// Block waits indefinitely for room in the queue.
+	Block = OverflowPolicy{kind: overflowBlock}
+)
+
+// BlockWithTimeout returns an OverflowPolicy that waits up to d for room
+// in the queue before giving up and dropping the entry.
+func BlockWithTimeout(d time.Duration) OverflowPolicy {
+	return OverflowPolicy{kind: overflowBlockWithTimeout, timeout: d}
+}
+
+// asyncEntry is a formatted line queued for delivery to the wrapped
+// logger's sinks.
+type asyncEntry struct {
+	level     log.Level
+	formatted []byte
+}
+
+// asyncSink queues formatted entries and hands them to inner's sinks from
+// a single writer goroutine, so Write never blocks on inner's own sinks
+// (except as OverflowPolicy dictates). Fatal and panic level entries
+// bypass the queue entirely.
+type asyncSink struct {
+	inner    *Logger
+	queue    chan asyncEntry
+	overflow OverflowPolicy
+
+	dropped int64
+
+	closeOnce sync.Once
+	done      chan struct{}
+	drainDone chan struct{}
+}
+
+// minQueueSize is the smallest queue capacity newAsyncSink will honor.
+// overflowDropOldest retries the send against a drain in a tight loop, so a
+// zero or negative queueSize (an unbuffered channel, where a send can never
+// succeed on its own) would spin a goroutine at 100% CPU; every other
+// overflow kind also just wants somewhere to put an entry.
+const minQueueSize = 1
+
+func newAsyncSink(inner *Logger, queueSize int, overflow OverflowPolicy) *asyncSink {
+	if queueSize < minQueueSize {
+		queueSize = minQueueSize
+	}
+
+	s := &asyncSink{
+		inner:     inner,
+		queue:     make(chan asyncEntry, queueSize),
+		overflow:  overflow,
+		done:      make(chan struct{}),
+		drainDone: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *asyncSink) run() {
+	defer close(s.drainDone)
+	for {
+		select {
+		case e := <-s.queue:
+			s.writeToInner(e)
+		case <-s.done:
+			s.drainQueue()
+			return
+		}
+	}
+}
+
+func (s *asyncSink) drainQueue() {
+	for {
+		select {
+		case e := <-s.queue:
+			s.writeToInner(e)
+		default:
+			return
+		}
+	}
+}
+
+func (s *asyncSink) writeToInner(e asyncEntry) {
+	for _, sink := range s.inner.sinks {
+		sink.Write(e.level, e.formatted)
+	}
+}
+
+func (s *asyncSink) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Write queues formatted for asynchronous delivery according to the
+// configured OverflowPolicy. Fatal and panic level entries bypass the
+// queue so the process doesn't exit before they are written.
+func (s *asyncSink) Write(level log.Level, formatted []byte) error {
+	entry := asyncEntry{level: level, formatted: formatted}
+
+	if level == log.FatalLevel || level == log.PanicLevel {
+		s.writeToInner(entry)
+		return nil
+	}
+
+	if s.closed() {
+		atomic.AddInt64(&s.dropped, 1)
+		return nil
+	}
+
+	switch s.overflow.kind {
+	case overflowDropNewest:
+		select {
+		case s.queue <- entry:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+
+	case overflowBlock:
+		select {
+		case s.queue <- entry:
+		case <-s.done:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+
+	case overflowBlockWithTimeout:
+		select {
+		case s.queue <- entry:
+		case <-time.After(s.overflow.timeout):
+			atomic.AddInt64(&s.dropped, 1)
+		case <-s.done:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+
+	default: // overflowDropOldest
+		for {
+			select {
+			case s.queue <- entry:
+				return nil
+			default:
+				select {
+				case <-s.queue:
+					atomic.AddInt64(&s.dropped, 1)
+				default:
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dropped returns the number of entries discarded due to a full queue or
+// because they arrived after Close.
+func (s *asyncSink) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// QueueLen returns the number of entries currently queued.
+func (s *asyncSink) QueueLen() int {
+	return len(s.queue)
+}
+
+// Flush waits, up to a deadline, for the queue to drain, then flushes
+// inner's sinks so durability guarantees (e.g. a buffered file sink) hold
+// once Flush returns.
+func (s *asyncSink) Flush() {
+	deadline := time.Now().Add(5 * time.Second)
+	for len(s.queue) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.inner.Flush()
+}
+
+// Close stops accepting new entries, drains whatever remains (up to a
+// deadline), and closes the wrapped logger's sinks.
+func (s *asyncSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+
+	select {
+	case <-s.drainDone:
+	case <-time.After(5 * time.Second):
+	}
+
+	s.inner.Flush()
+
+	return s.inner.Close()
+}
+
+// Reopen reopens the wrapped logger's sinks.
+func (s *asyncSink) Reopen() error {
+	return s.inner.Reopen()
+}
+
+// NewAsyncLogger creates a logger that pushes every entry onto a bounded,
+// buffered queue drained by a single writer goroutine, so Debug/Info/
+// Error/etc. don't block on inner's sinks (a slow disk or network sink,
+// for example). overflow controls what happens once the queue is full.
+// queueSize is clamped to at least minQueueSize.
+func NewAsyncLogger(inner *Logger, queueSize int, overflow OverflowPolicy) *Logger {
+	sink := newAsyncSink(inner, queueSize, overflow)
+
+	l, _ := NewLogger([]Sink{sink})
+	l.origLogger.Formatter = inner.origLogger.Formatter
+	l.SetLevel(inner.GetLevel())
+
+	return l
+}
+
+// Dropped returns the number of entries this logger's async sink has
+// discarded due to a full queue or because they arrived after Close, or 0
+// if it wasn't created with NewAsyncLogger.
+func (l *Logger) Dropped() int64 {
+	for _, sink := range l.sinks {
+		if as, ok := sink.(*asyncSink); ok {
+			return as.Dropped()
+		}
+	}
+	return 0
+}
+
+// QueueLen returns the number of entries currently queued by this
+// logger's async sink, or 0 if it wasn't created with NewAsyncLogger.
+func (l *Logger) QueueLen() int {
+	for _, sink := range l.sinks {
+		if as, ok := sink.(*asyncSink); ok {
+			return as.QueueLen()
+		}
+	}
+	return 0
+}
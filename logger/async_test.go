@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitFor(d time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return cond()
+}
+
+func TestNewAsyncLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	inner, err := NewMultiSinkLogger(NewWriterSink(&buf))
+	assert.NoError(t, err)
+	inner.SetLevel("debug")
+
+	async := NewAsyncLogger(inner, 16, DropOldest)
+	async.SetLevel("debug")
+
+	for i := 0; i < 5; i++ {
+		async.Info("queued line")
+	}
+	async.Flush()
+
+	assert.True(t, waitFor(time.Second, func() bool {
+		return strings.Count(buf.String(), "queued line") == 5
+	}))
+	assert.Equal(t, int64(0), async.Dropped())
+}
+
+func TestNewAsyncLoggerOverflow(t *testing.T) {
+	var buf bytes.Buffer
+
+	inner, err := NewMultiSinkLogger(NewWriterSink(&buf))
+	assert.NoError(t, err)
+	inner.SetLevel("debug")
+
+	async := NewAsyncLogger(inner, 1, DropNewest)
+	async.SetLevel("debug")
+
+	for i := 0; i < 50; i++ {
+		async.Info("burst line")
+	}
+
+	assert.True(t, waitFor(time.Second, func() bool {
+		return async.QueueLen() == 0
+	}))
+}
+
+// flushRecorder is an io.Writer that also implements Flusher, so
+// writerSink.Flush forwards to it; used to confirm asyncSink.Flush/Close
+// propagate to inner's sinks rather than stopping at the queue.
+type flushRecorder struct {
+	bytes.Buffer
+	flushed int
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushed++
+}
+
+func TestNewAsyncLoggerFlushPropagatesToInner(t *testing.T) {
+	rec := &flushRecorder{}
+
+	inner, err := NewMultiSinkLogger(NewWriterSink(rec))
+	assert.NoError(t, err)
+	inner.SetLevel("debug")
+
+	async := NewAsyncLogger(inner, 16, DropOldest)
+	async.SetLevel("debug")
+
+	async.Info("buffered line")
+	async.Flush()
+
+	assert.True(t, waitFor(time.Second, func() bool {
+		return rec.flushed > 0
+	}), "asyncSink.Flush should flush inner's sinks, not just drain the queue")
+}
+
+func TestNewAsyncLoggerCloseFlushesInner(t *testing.T) {
+	rec := &flushRecorder{}
+
+	inner, err := NewMultiSinkLogger(NewWriterSink(rec))
+	assert.NoError(t, err)
+	inner.SetLevel("debug")
+
+	async := NewAsyncLogger(inner, 16, DropOldest)
+	async.SetLevel("debug")
+
+	async.Info("buffered line")
+	assert.NoError(t, async.Close())
+
+	assert.True(t, rec.flushed > 0, "asyncSink.Close should flush inner's sinks before closing")
+}
+
+func TestNewAsyncLoggerClampsQueueSize(t *testing.T) {
+	var buf bytes.Buffer
+
+	inner, err := NewMultiSinkLogger(NewWriterSink(&buf))
+	assert.NoError(t, err)
+	inner.SetLevel("debug")
+
+	async := NewAsyncLogger(inner, 0, DropOldest)
+	async.SetLevel("debug")
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 20; i++ {
+			async.Info("line")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Write with queueSize <= 0 did not return; DropOldest is spinning on an unbuffered channel")
+	}
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// callerBaseCache caches the short basename of a caller's source file,
+// keyed by program counter, so ReportCaller doesn't pay for a
+// filepath.Base call on every log line.
+var callerBaseCache sync.Map
+
+// callerInfo resolves the short "file:line" of the call skip frames above
+// the runtime.Caller call below, or "" if it can't be resolved.
+func callerInfo(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+
+	var base string
+	if cached, ok := callerBaseCache.Load(pc); ok {
+		base = cached.(string)
+	} else {
+		base = filepath.Base(file)
+		callerBaseCache.Store(pc, base)
+	}
+
+	return fmt.Sprintf("%s:%d", base, line)
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCaller(t *testing.T) {
+	logFile := "/tmp/logger-test/caller.log"
+	dir := path.Dir(logFile)
+	err := os.MkdirAll(dir, 0775)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := NewFileLogger(logFile, "debug")
+	assert.NoError(t, err)
+
+	l.ReportCaller = true
+	l.Info("with caller")
+
+	raw, err := ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(raw), "caller_test.go:"))
+}
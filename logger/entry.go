@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// outputMessage formats the message with the optional arguments, if any,
+// before handing it to the given logrus log function.
+func outputMessage(origin func(...interface{}), formatOrMessage string, v ...interface{}) {
+	if len(v) > 0 {
+		origin(fmt.Sprintf(formatOrMessage, v...))
+	} else {
+		origin(formatOrMessage)
+	}
+}
+
+// Entry wraps a logrus entry carrying structured fields attached via
+// Logger.WithFields, so callers can chain a message call after it.
+type Entry struct {
+	logger    *Logger
+	origEntry *log.Entry
+}
+
+// WithFields attaches structured context (e.g. request IDs, user IDs, trace
+// IDs) to the entry that will be emitted by the returned Entry's methods.
+func (l *Logger) WithFields(fields map[string]interface{}) *Entry {
+	return &Entry{
+		logger:    l,
+		origEntry: l.origLogger.WithFields(log.Fields(fields)),
+	}
+}
+
+// Debug logs a message with severity DEBUG.
+func (e *Entry) Debug(message string) {
+	outputMessage(e.origEntry.Debug, message)
+}
+
+// DebugF logs a message with severity DEBUG in format.
+func (e *Entry) DebugF(format string, v ...interface{}) {
+	outputMessage(e.origEntry.Debug, format, v...)
+}
+
+// Info logs a message with severity INFO.
+func (e *Entry) Info(message string) {
+	outputMessage(e.origEntry.Info, message)
+}
+
+// InfoF logs a message with severity INFO in format.
+func (e *Entry) InfoF(format string, v ...interface{}) {
+	outputMessage(e.origEntry.Info, format, v...)
+}
+
+// Warn logs a message with severity WARN.
+func (e *Entry) Warn(message string) {
+	outputMessage(e.origEntry.Warn, message)
+}
+
+// WarnF logs a message with severity WARN in format.
+func (e *Entry) WarnF(format string, v ...interface{}) {
+	outputMessage(e.origEntry.Warn, format, v...)
+}
+
+// Error logs a message with severity ERROR.
+func (e *Entry) Error(message string) {
+	outputMessage(e.origEntry.Error, message)
+}
+
+// ErrorF logs a message with severity ERROR in format.
+func (e *Entry) ErrorF(format string, v ...interface{}) {
+	outputMessage(e.origEntry.Error, format, v...)
+}
+
+// Fatal logs a message with severity FATAL followed by a call to os.Exit().
+func (e *Entry) Fatal(message string) {
+	outputMessage(e.origEntry.Fatal, message)
+}
+
+// FatalF logs a message with severity FATAL in format followed by a call to
+// os.Exit().
+func (e *Entry) FatalF(format string, v ...interface{}) {
+	outputMessage(e.origEntry.Fatal, format, v...)
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/pengsrc/go-utils/convert"
+)
+
+// JSONFormatter is used to format a log entry as a single line of JSON,
+// suitable for log pipelines such as ELK, Loki, or Stackdriver.
+type JSONFormatter struct {
+	// TimeFieldName overrides the default "time" field name.
+	TimeFieldName string
+	// LevelFieldName overrides the default "level" field name.
+	LevelFieldName string
+}
+
+// Format formats a given log entry, returns byte slice and error.
+func (f *JSONFormatter) Format(entry *log.Entry) ([]byte, error) {
+	timeFieldName := f.TimeFieldName
+	if timeFieldName == "" {
+		timeFieldName = "time"
+	}
+	levelFieldName := f.LevelFieldName
+	if levelFieldName == "" {
+		levelFieldName = "level"
+	}
+
+	data := make(log.Fields, len(entry.Data)+4)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	data[timeFieldName] = convert.TimeToString(time.Now(), convert.ISO8601Milli)
+	data["pid"] = os.Getpid()
+	data[levelFieldName] = strings.ToUpper(entry.Level.String())
+	data["message"] = entry.Message
+
+	serialized, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry to JSON: %v", err)
+	}
+	return append(serialized, '\n'), nil
+}
+
+// NewJSONFormatter creates a new JSON log formatter.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
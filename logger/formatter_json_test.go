@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONFormatterFields(t *testing.T) {
+	logFile := "/tmp/logger-test/json.log"
+	dir := path.Dir(logFile)
+	err := os.MkdirAll(dir, 0775)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := NewFileLogger(logFile, "debug")
+	assert.NoError(t, err)
+	l.origLogger.Formatter = NewJSONFormatter()
+
+	l.WithFields(map[string]interface{}{
+		"request_id": "abc-123",
+		"user_id":    42,
+	}).InfoF("handled request")
+
+	l.Flush()
+
+	raw, err := ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(raw, &fields))
+	assert.Equal(t, "abc-123", fields["request_id"])
+	assert.Equal(t, float64(42), fields["user_id"])
+	assert.Equal(t, "handled request", fields["message"])
+	assert.Equal(t, "INFO", fields["level"])
+}
@@ -0,0 +1,90 @@
+// Package kafka provides a logger.Sink that publishes to a Kafka topic. It
+// is kept out of the base logger package so that consumers who only log to
+// a file or terminal don't transitively pull in sarama and its
+// dependencies.
+package kafka
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/pengsrc/go-shared/logger"
+)
+
+// Sink batches formatted log lines onto a Kafka topic using an async
+// producer, so writes never block on a broker round trip.
+type Sink struct {
+	logger.LevelFilter
+
+	topic    string
+	producer sarama.AsyncProducer
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewSink creates a Sink that publishes to topic over the given Kafka
+// brokers.
+func NewSink(brokers []string, topic string, levels ...log.Level) (*Sink, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = false
+	config.Producer.Return.Errors = false
+	config.Producer.RequiredAcks = sarama.WaitForLocal
+
+	producer, err := sarama.NewAsyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sink{
+		LevelFilter: logger.NewLevelFilter(levels...),
+		topic:       topic,
+		producer:    producer,
+	}, nil
+}
+
+// Write enqueues formatted onto the topic; the producer batches messages
+// internally before sending them to the broker.
+func (s *Sink) Write(level log.Level, formatted []byte) error {
+	if !s.Allowed(level) {
+		return nil
+	}
+
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil
+	}
+
+	s.producer.Input() <- &sarama.ProducerMessage{
+		Topic: s.topic,
+		Value: sarama.ByteEncoder(formatted),
+	}
+	return nil
+}
+
+// Flush is a no-op; the sarama async producer flushes on its own
+// schedule.
+func (s *Sink) Flush() {}
+
+// Close shuts the producer down, flushing any in-flight batch.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.producer.Close()
+}
+
+// Reopen is a no-op; the sarama producer manages its own broker
+// connections and reconnects transparently.
+func (s *Sink) Reopen() error {
+	return nil
+}
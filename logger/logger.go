@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"path"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -31,13 +33,23 @@ func (c *LogFormatter) Format(entry *log.Entry) ([]byte, error) {
 		level = strings.Repeat(" ", 5-len(level)) + level
 	}
 
+	// The "caller" field is only ever set by Logger.output when
+	// Logger.ReportCaller is enabled, so its mere presence is enough to
+	// decide whether to render it; no separate formatter-level flag is
+	// needed (and mutating one from output() would race across
+	// goroutines sharing this formatter).
+	message := entry.Message
+	if caller, ok := entry.Data["caller"].(string); ok && caller != "" {
+		message = fmt.Sprintf("%s %s", caller, message)
+	}
+
 	return []byte(
 		fmt.Sprintf(
 			"[%s #%d] %s -- : %s\n",
 			convert.TimeToString(time.Now(), convert.ISO8601Milli),
 			os.Getpid(),
 			level,
-			entry.Message,
+			message,
 		),
 	), nil
 }
@@ -91,8 +103,23 @@ func NewErrorHook(out io.Writer) *ErrorHook {
 type Logger struct {
 	origLogger *log.Logger
 
-	out         io.Writer
-	bufferedOut Flusher
+	sinks []Sink
+
+	// ReportCaller attaches the file:line of the Go source that invoked
+	// Info/Error/etc. as the entry's "caller" field, which LogFormatter
+	// renders as a prefix of the message. Entries logged through
+	// WithFields carry no caller.
+	ReportCaller bool
+	// CallerSkip adds to the number of stack frames skipped when
+	// resolving the caller, for callers that wrap Logger behind another
+	// layer of helper functions.
+	CallerSkip int
+
+	verbosity   int32
+	vGeneration int32
+	vmoduleMu   sync.RWMutex
+	vmodule     []vmoduleRule
+	vCache      sync.Map
 }
 
 // Flusher defines a interface with Flush() method.
@@ -116,67 +143,115 @@ func (l *Logger) SetLevel(level string) {
 
 // Flush writes buffered logs.
 func (l *Logger) Flush() {
-	if l.bufferedOut != nil {
-		l.bufferedOut.Flush()
+	for _, sink := range l.sinks {
+		sink.Flush()
 	}
 }
 
+// Close closes every sink backing this logger, releasing any resources
+// they hold (open connections, file handles, etc).
+func (l *Logger) Close() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Reopen reopens every sink backing this logger, e.g. after external log
+// rotation or a dropped connection.
+func (l *Logger) Reopen() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Reopen(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // Debug logs a message with severity DEBUG.
 func (l *Logger) Debug(message string) {
-	l.output(l.origLogger.Debug, message)
+	l.output(log.DebugLevel, message)
 }
 
 // DebugF logs a message with severity DEBUG in format.
 func (l *Logger) DebugF(format string, v ...interface{}) {
-	l.output(l.origLogger.Debug, format, v...)
+	l.output(log.DebugLevel, format, v...)
 }
 
 // Info logs a message with severity INFO.
 func (l *Logger) Info(message string) {
-	l.output(l.origLogger.Info, message)
+	l.output(log.InfoLevel, message)
 }
 
 // InfoF logs a message with severity INFO in format.
 func (l *Logger) InfoF(format string, v ...interface{}) {
-	l.output(l.origLogger.Info, format, v...)
+	l.output(log.InfoLevel, format, v...)
 }
 
 // Warn logs a message with severity WARN.
 func (l *Logger) Warn(message string) {
-	l.output(l.origLogger.Warn, message)
+	l.output(log.WarnLevel, message)
 }
 
 // WarnF logs a message with severity WARN in format.
 func (l *Logger) WarnF(format string, v ...interface{}) {
-	l.output(l.origLogger.Warn, format, v...)
+	l.output(log.WarnLevel, format, v...)
 }
 
 // Error logs a message with severity ERROR.
 func (l *Logger) Error(message string) {
-	l.output(l.origLogger.Error, message)
+	l.output(log.ErrorLevel, message)
 }
 
 // ErrorF logs a message with severity ERROR in format.
 func (l *Logger) ErrorF(format string, v ...interface{}) {
-	l.output(l.origLogger.Error, format, v...)
+	l.output(log.ErrorLevel, format, v...)
 }
 
 // Fatal logs a message with severity ERROR followed by a call to os.Exit().
 func (l *Logger) Fatal(message string) {
-	l.output(l.origLogger.Fatal, message)
+	l.output(log.FatalLevel, message)
 }
 
 // FatalF logs a message with severity ERROR in format followed by a call to
 // os.Exit().
 func (l *Logger) FatalF(format string, v ...interface{}) {
-	l.output(l.origLogger.Fatal, format, v...)
+	l.output(log.FatalLevel, format, v...)
 }
 
-func (l *Logger) output(origin func(...interface{}), formatOrMessage string, v ...interface{}) {
+// callerSkipBase is the number of stack frames between the runtime.Caller
+// call inside callerInfo and the user code calling Debug/Info/Warn/Error/
+// Fatal: callerInfo -> output -> Debug (or its siblings) -> user code.
+const callerSkipBase = 3
+
+func (l *Logger) output(level log.Level, formatOrMessage string, v ...interface{}) {
+	message := formatOrMessage
 	if len(v) > 0 {
-		origin(fmt.Sprintf(formatOrMessage, v...))
-	} else {
-		origin(formatOrMessage)
+		message = fmt.Sprintf(formatOrMessage, v...)
+	}
+
+	entry := log.NewEntry(l.origLogger)
+	if l.ReportCaller {
+		if caller := callerInfo(callerSkipBase + l.CallerSkip); caller != "" {
+			entry = entry.WithField("caller", caller)
+		}
+	}
+
+	switch level {
+	case log.DebugLevel:
+		entry.Debug(message)
+	case log.InfoLevel:
+		entry.Info(message)
+	case log.WarnLevel:
+		entry.Warn(message)
+	case log.ErrorLevel:
+		entry.Error(message)
+	case log.FatalLevel:
+		entry.Fatal(message)
 	}
 }
 
@@ -213,7 +288,7 @@ func NewFileLogger(filePath string, level ...string) (*Logger, error) {
 	}()
 	signal.Notify(c, syscall.SIGHUP)
 
-	return NewLogger(out, level...)
+	return NewLogger([]Sink{NewWriterSink(out)}, level...)
 }
 
 // NewBufferedFileLogger creates a logger that write into files with buffer.
@@ -245,35 +320,33 @@ func NewBufferedFileLogger(filePath string, level ...string) (*Logger, error) {
 	}()
 	signal.Notify(c, syscall.SIGHUP)
 
-	l, err := NewLogger(bufferedOut, level...)
-	if err != nil {
-		return nil, err
-	}
-
-	l.bufferedOut = bufferedOut
-
-	return l, nil
+	return NewLogger([]Sink{NewWriterSink(bufferedOut)}, level...)
 }
 
 // NewTerminalLogger creates a logger that write into terminal.
 func NewTerminalLogger(level ...string) (*Logger, error) {
-	return NewLogger(os.Stdout, level...)
+	return NewLogger([]Sink{NewWriterSink(os.Stdout)}, level...)
 }
 
-// NewLogger creates a new logger for given out and level, and the level is
-// optional.
-func NewLogger(out io.Writer, level ...string) (*Logger, error) {
-	if out == nil {
-		return nil, errors.New(`must specify the output for logger`)
+// NewLogger creates a new logger that writes to the given sinks, and the
+// level is optional.
+func NewLogger(sinks []Sink, level ...string) (*Logger, error) {
+	if len(sinks) == 0 {
+		return nil, errors.New(`must specify at least one sink for logger`)
 	}
+
 	l := &Logger{
 		origLogger: &log.Logger{
-			Out:       out,
+			Out:       ioutil.Discard,
 			Formatter: NewLogFormatter(),
 			Hooks:     log.LevelHooks{},
 			Level:     log.WarnLevel,
 		},
-		out: out,
+		sinks: sinks,
+	}
+
+	for _, sink := range sinks {
+		l.origLogger.Hooks.Add(&sinkHook{logger: l, sink: sink})
 	}
 
 	if len(level) == 1 {
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRotatingFileLoggerSizeBased(t *testing.T) {
+	logFile := "/tmp/logger-test/rotate.log"
+	dir := path.Dir(logFile)
+	err := os.MkdirAll(dir, 0775)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := NewRotatingFileLogger(logFile, RotateOptions{MaxSizeBytes: 1}, "debug")
+	assert.NoError(t, err)
+
+	l.Info("first line triggers the next rotation")
+	l.Info("second line lands in a fresh backup")
+
+	backups, err := filepath.Glob(logFile + ".*")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, backups)
+
+	_, err = os.Stat(logFile)
+	assert.NoError(t, err)
+}
+
+func TestNextBoundaryAlignsToWallClock(t *testing.T) {
+	b := nextBoundary(time.Hour)
+	assert.True(t, b.After(time.Now()))
+	assert.Equal(t, 0, b.Minute())
+	assert.Equal(t, 0, b.Second())
+}
+
+func TestUniqueBackupPathDisambiguatesCollisions(t *testing.T) {
+	logFile := "/tmp/logger-test/unique-backup.log"
+	dir := path.Dir(logFile)
+	err := os.MkdirAll(dir, 0775)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Equal(t, logFile, uniqueBackupPath(logFile))
+
+	err = ioutil.WriteFile(logFile, []byte("first"), 0644)
+	assert.NoError(t, err)
+	assert.Equal(t, logFile+".1", uniqueBackupPath(logFile))
+
+	err = ioutil.WriteFile(logFile+".1", []byte("second"), 0644)
+	assert.NoError(t, err)
+	assert.Equal(t, logFile+".2", uniqueBackupPath(logFile))
+}
+
+func TestNewRotatingFileLoggerSameSecondBackupsDontClobber(t *testing.T) {
+	logFile := "/tmp/logger-test/rotate-collide.log"
+	dir := path.Dir(logFile)
+	err := os.MkdirAll(dir, 0775)
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := NewRotatingFileLogger(logFile, RotateOptions{MaxSizeBytes: 1}, "debug")
+	assert.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		l.Info("line that triggers a rotation")
+	}
+
+	backups, err := filepath.Glob(logFile + ".*")
+	assert.NoError(t, err)
+	assert.True(t, len(backups) >= 4, "each rotation should produce its own backup, even within the same second")
+
+	var total int
+	for _, backup := range backups {
+		data, err := ioutil.ReadFile(backup)
+		assert.NoError(t, err)
+		total += len(data)
+	}
+	assert.NotZero(t, total)
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"io"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// Sink is a destination for formatted log output. A Logger built with
+// NewLogger or NewMultiSinkLogger fans every entry out to each of its
+// sinks; a sink is responsible for deciding, from the level passed to
+// Write, whether a given line should actually be persisted (see
+// levelSet).
+type Sink interface {
+	// Write writes an already-formatted log line produced at level.
+	Write(level log.Level, formatted []byte) error
+	// Flush flushes any buffered data.
+	Flush()
+	// Close releases any resources held by the sink.
+	Close() error
+	// Reopen reopens the underlying resource, e.g. after log rotation or
+	// a dropped connection.
+	Reopen() error
+}
+
+// levelSet restricts a Sink to a subset of levels. Embed it in concrete
+// sink implementations to get a ready-made allowed check; an empty set
+// means every level is allowed.
+type levelSet struct {
+	levels []log.Level
+}
+
+func newLevelSet(levels []log.Level) levelSet {
+	return levelSet{levels: levels}
+}
+
+func (s levelSet) allowed(level log.Level) bool {
+	if len(s.levels) == 0 {
+		return true
+	}
+	for _, l := range s.levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// LevelFilter restricts a Sink to a subset of levels, the same way the
+// built-in sinks use levelSet. It's exported so Sink implementations that
+// live outside this package (e.g. logger/kafka.Sink, kept in its own
+// subpackage so the base logger doesn't pull in its dependencies) can embed
+// it instead of duplicating the filtering logic.
+type LevelFilter struct {
+	levelSet
+}
+
+// NewLevelFilter returns a LevelFilter allowing levels, or every level if
+// none are given.
+func NewLevelFilter(levels ...log.Level) LevelFilter {
+	return LevelFilter{levelSet: newLevelSet(levels)}
+}
+
+// Allowed reports whether level is one of the filter's configured levels.
+func (f LevelFilter) Allowed(level log.Level) bool {
+	return f.levelSet.allowed(level)
+}
+
+// writerSink adapts a plain io.Writer into the Sink interface. It is used
+// internally so the pre-existing File/Terminal constructors keep working
+// unchanged on top of the sink-based Logger.
+type writerSink struct {
+	levelSet
+	out io.Writer
+}
+
+// NewWriterSink adapts out into a Sink restricted to levels (or every
+// level, if none are given).
+func NewWriterSink(out io.Writer, levels ...log.Level) Sink {
+	return &writerSink{levelSet: newLevelSet(levels), out: out}
+}
+
+func (s *writerSink) Write(level log.Level, formatted []byte) error {
+	if !s.allowed(level) {
+		return nil
+	}
+	_, err := s.out.Write(formatted)
+	return err
+}
+
+func (s *writerSink) Flush() {
+	if f, ok := s.out.(Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *writerSink) Close() error {
+	if c, ok := s.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (s *writerSink) Reopen() error {
+	if r, ok := s.out.(interface{ Reopen() error }); ok {
+		return r.Reopen()
+	}
+	return nil
+}
+
+// sinkHook adapts a Sink to a logrus hook: it formats every entry using
+// the owning logger's current formatter and hands the bytes to the sink,
+// which decides for itself whether to persist them.
+type sinkHook struct {
+	logger *Logger
+	sink   Sink
+}
+
+// Levels returns every level; filtering is delegated to the sink.
+func (h *sinkHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire formats entry and writes it to the sink.
+func (h *sinkHook) Fire(entry *log.Entry) error {
+	formatted, err := h.logger.origLogger.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	return h.sink.Write(entry.Level, formatted)
+}
+
+// NewMultiSinkLogger creates a logger that fans every entry out to all of
+// the given sinks, e.g. so that errors go to syslog and stderr while info
+// goes to Kafka. Which levels a sink actually persists is up to the sink
+// itself (see NewWriterSink and the level filters used by the built-in
+// sinks).
+func NewMultiSinkLogger(sinks ...Sink) (*Logger, error) {
+	return NewLogger(sinks)
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// ConnSink writes formatted log lines to a TCP or UDP connection,
+// transparently reconnecting with exponential backoff if the connection
+// drops or was never established (a style of writer familiar from
+// beego's conn log adapter).
+type ConnSink struct {
+	levelSet
+
+	network string
+	addr    string
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewConnSink creates a ConnSink that dials network/addr (e.g.
+// "tcp", "host:port") lazily on the first write. On failure it retries
+// with backoff starting at minBackoff and doubling up to maxBackoff.
+func NewConnSink(network, addr string, minBackoff, maxBackoff time.Duration, levels ...log.Level) *ConnSink {
+	if minBackoff <= 0 {
+		minBackoff = 100 * time.Millisecond
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	return &ConnSink{
+		levelSet:   newLevelSet(levels),
+		network:    network,
+		addr:       addr,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// Write writes formatted over the connection, dialing first if there is
+// no live connection.
+func (s *ConnSink) Write(level log.Level, formatted []byte) error {
+	if !s.allowed(level) {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.dialLocked(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.conn.Write(formatted); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+// dialLocked connects with exponential backoff, doubling from minBackoff
+// up to maxBackoff before giving up. Callers must hold s.mu.
+func (s *ConnSink) dialLocked() error {
+	backoff := s.minBackoff
+
+	for {
+		conn, err := net.Dial(s.network, s.addr)
+		if err == nil {
+			s.conn = conn
+			return nil
+		}
+
+		if backoff > s.maxBackoff {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// Flush is a no-op; ConnSink does not buffer.
+func (s *ConnSink) Flush() {}
+
+// Close closes the underlying connection, if any.
+func (s *ConnSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// Reopen drops the current connection so the next Write redials.
+func (s *ConnSink) Reopen() error {
+	return s.Close()
+}
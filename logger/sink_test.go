@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMultiSinkLogger(t *testing.T) {
+	var everything bytes.Buffer
+	var errorsOnly bytes.Buffer
+
+	l, err := NewMultiSinkLogger(
+		NewWriterSink(&everything),
+		NewWriterSink(&errorsOnly, log.ErrorLevel, log.FatalLevel, log.PanicLevel),
+	)
+	assert.NoError(t, err)
+	l.SetLevel("debug")
+
+	l.Info("info line")
+	l.Error("error line")
+
+	assert.Equal(t, 2, len(strings.Split(strings.TrimRight(everything.String(), "\n"), "\n")))
+	assert.Equal(t, 1, len(strings.Split(strings.TrimRight(errorsOnly.String(), "\n"), "\n")))
+	assert.Contains(t, errorsOnly.String(), "error line")
+}
+
+func TestNewLoggerRequiresSinks(t *testing.T) {
+	_, err := NewLogger(nil)
+	assert.Error(t, err)
+}
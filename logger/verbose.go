@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// Verbose is returned by Logger.V and records whether a given verbosity
+// level is enabled for the calling file. Its methods are no-ops when the
+// level is disabled, skipping the Sprintf and the write to the
+// underlying sinks, e.g.:
+//
+//	l.V(2).InfoF("expensive: %v", computeExpensiveThing())
+//
+// Note that Go evaluates arguments before the call, so computeExpensiveThing()
+// above still runs even when V(2) is disabled; only the formatting and
+// writing are skipped. Wrap genuinely expensive computation in an
+// `if l.V(2).Enabled() { ... }`-style guard instead (see Enabled).
+type Verbose struct {
+	enabled bool
+	logger  *Logger
+}
+
+// Enabled reports whether this verbosity level is enabled, for guarding
+// computation that is too expensive to simply pass as an argument.
+func (v Verbose) Enabled() bool {
+	return v.enabled
+}
+
+// Info logs a message with severity INFO if the verbosity level is enabled.
+func (v Verbose) Info(message string) {
+	if v.enabled {
+		v.logger.output(log.InfoLevel, message)
+	}
+}
+
+// InfoF logs a message with severity INFO in format if the verbosity level
+// is enabled.
+func (v Verbose) InfoF(format string, a ...interface{}) {
+	if v.enabled {
+		v.logger.output(log.InfoLevel, format, a...)
+	}
+}
+
+// vmoduleRule associates a glob pattern over a source file path (or its
+// base name) with a verbosity level.
+type vmoduleRule struct {
+	pattern string
+	level   int32
+}
+
+// vCacheEntry is the per-call-site cache of a resolved verbosity level. It
+// is invalidated by comparing generation against Logger.vGeneration, which
+// SetVerbosity and SetVModule bump.
+type vCacheEntry struct {
+	generation int32
+	level      int32
+}
+
+// SetVerbosity sets the default verbosity level used by V when no vmodule
+// pattern matches the caller's file.
+func (l *Logger) SetVerbosity(level int) {
+	atomic.StoreInt32(&l.verbosity, int32(level))
+	atomic.AddInt32(&l.vGeneration, 1)
+}
+
+// SetVModule sets per-file or per-package verbosity overrides. pattern is a
+// comma-separated list of glob=level pairs, e.g.
+// "ingest*=3,cache.go=2,pkg/db/*=1". Patterns containing a "/" are matched
+// against as many trailing segments of the caller's file path as the
+// pattern has (see pathSuffix); patterns without one are matched against
+// the file's base name.
+func (l *Logger) SetVModule(pattern string) error {
+	var rules []vmoduleRule
+	for _, part := range strings.Split(pattern, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf(`invalid vmodule pattern: "%s"`, part)
+		}
+
+		level, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return fmt.Errorf(`invalid vmodule level in "%s": %v`, part, err)
+		}
+
+		rules = append(rules, vmoduleRule{
+			pattern: strings.TrimSpace(kv[0]),
+			level:   int32(level),
+		})
+	}
+
+	l.vmoduleMu.Lock()
+	l.vmodule = rules
+	l.vmoduleMu.Unlock()
+
+	atomic.AddInt32(&l.vGeneration, 1)
+	return nil
+}
+
+// V reports whether verbosity level is enabled for the caller's file. The
+// computed level is cached per call site (keyed by program counter) until
+// SetVerbosity or SetVModule invalidates it, so the glob matching in
+// vLevelForFile only runs once per call site.
+func (l *Logger) V(level int) Verbose {
+	pc, file, _, ok := runtime.Caller(1)
+	if !ok {
+		return Verbose{enabled: int32(level) <= atomic.LoadInt32(&l.verbosity), logger: l}
+	}
+
+	generation := atomic.LoadInt32(&l.vGeneration)
+
+	if cached, ok := l.vCache.Load(pc); ok {
+		entry := cached.(vCacheEntry)
+		if entry.generation == generation {
+			return Verbose{enabled: int32(level) <= entry.level, logger: l}
+		}
+	}
+
+	resolved := l.vLevelForFile(file)
+	l.vCache.Store(pc, vCacheEntry{generation: generation, level: resolved})
+
+	return Verbose{enabled: int32(level) <= resolved, logger: l}
+}
+
+func (l *Logger) vLevelForFile(file string) int32 {
+	l.vmoduleMu.RLock()
+	rules := l.vmodule
+	l.vmoduleMu.RUnlock()
+
+	base := filepath.Base(file)
+	for _, rule := range rules {
+		if strings.Contains(rule.pattern, "/") {
+			if matched, _ := filepath.Match(rule.pattern, pathSuffix(file, rule.pattern)); matched {
+				return rule.level
+			}
+			continue
+		}
+		if matched, _ := filepath.Match(rule.pattern, base); matched {
+			return rule.level
+		}
+	}
+
+	return atomic.LoadInt32(&l.verbosity)
+}
+
+// pathSuffix returns the trailing path segments of file with the same
+// number of segments as pattern. runtime.Caller returns an absolute
+// path, and filepath.Match anchors the whole string while "*" won't
+// cross a "/", so a pattern like "pkg/db/*" can only ever match a
+// path of exactly its own shape; trimming file down to that shape
+// first is what lets it match regardless of where the repo lives on
+// disk.
+func pathSuffix(file, pattern string) string {
+	fileParts := strings.Split(filepath.ToSlash(file), "/")
+	patternParts := strings.Split(pattern, "/")
+
+	if len(patternParts) >= len(fileParts) {
+		return filepath.ToSlash(file)
+	}
+
+	return strings.Join(fileParts[len(fileParts)-len(patternParts):], "/")
+}
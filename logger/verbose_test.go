@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerbosity(t *testing.T) {
+	l, err := NewTerminalLogger("debug")
+	assert.NoError(t, err)
+
+	l.SetVerbosity(2)
+	assert.True(t, l.V(1).enabled)
+	assert.True(t, l.V(2).enabled)
+	assert.False(t, l.V(3).enabled)
+
+	l.SetVerbosity(0)
+	assert.False(t, l.V(1).enabled)
+}
+
+func TestSetVModule(t *testing.T) {
+	l, err := NewTerminalLogger("debug")
+	assert.NoError(t, err)
+
+	err = l.SetVModule("verbose_test.go=3")
+	assert.NoError(t, err)
+	assert.True(t, l.V(3).enabled)
+
+	err = l.SetVModule("not-a-valid-pattern")
+	assert.Error(t, err)
+}
+
+func TestSetVModuleSlashPattern(t *testing.T) {
+	_, file, _, ok := runtime.Caller(0)
+	assert.True(t, ok)
+
+	dir := filepath.Base(filepath.Dir(file))
+	pattern := dir + "/*"
+
+	l, err := NewTerminalLogger("debug")
+	assert.NoError(t, err)
+
+	err = l.SetVModule(pattern + "=3")
+	assert.NoError(t, err)
+	assert.True(t, l.V(3).enabled, "pattern %q should have matched %q", pattern, file)
+}